@@ -0,0 +1,184 @@
+package p2pd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	bsmsg "github.com/ipfs/go-bitswap/message"
+	bsmsgpb "github.com/ipfs/go-bitswap/message/pb"
+	cid "github.com/ipfs/go-cid"
+	ggio "github.com/gogo/protobuf/io"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	multiaddr "github.com/multiformats/go-multiaddr"
+	msgio "github.com/multiformats/go-msgio"
+
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+)
+
+// bitswapProtocol is the protocol ID used to probe providers for block
+// availability, as in the ipfs-check tool.
+const bitswapProtocol = "/ipfs/bitswap/1.2.0"
+
+// bitswapProbeTimeout bounds how long CheckCID waits for a single provider
+// to reply to a bitswap want-have, so one unresponsive provider can't hang
+// the whole check.
+const bitswapProbeTimeout = 15 * time.Second
+
+// connectProbeTimeout bounds how long CheckCID waits for the dial to a
+// single provider, so one slow or unreachable provider can't stall the
+// whole check.
+const connectProbeTimeout = 15 * time.Second
+
+// ProviderCheck is the result of probing a single DHT-advertised provider
+// of a CID.
+type ProviderCheck struct {
+	PeerID          peer.ID
+	DHTMaddrs       []multiaddr.Multiaddr
+	ConnectedMaddrs []multiaddr.Multiaddr
+	ConnectionError string
+	DataAvailable   bool
+	BitswapError    string
+}
+
+// CheckCID queries the DHT for providers of c, dials each one, and probes
+// it over bitswap to confirm it actually has the block.
+func (d *Daemon) CheckCID(ctx context.Context, c cid.Cid) ([]*ProviderCheck, error) {
+	if d.dht == nil {
+		return nil, fmt.Errorf("DHT is not enabled")
+	}
+
+	ch, err := d.FindProviders(c, defaultFindProvidersCount)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []*ProviderCheck
+	for ai := range ch {
+		result := &ProviderCheck{
+			PeerID:    ai.ID,
+			DHTMaddrs: ai.Addrs,
+		}
+
+		connectCtx, cancel := context.WithTimeout(ctx, connectProbeTimeout)
+		err := d.host.Connect(connectCtx, ai)
+		cancel()
+		if err != nil {
+			result.ConnectionError = err.Error()
+			results = append(results, result)
+			continue
+		}
+		for _, conn := range d.host.Network().ConnsToPeer(ai.ID) {
+			result.ConnectedMaddrs = append(result.ConnectedMaddrs, conn.RemoteMultiaddr())
+		}
+
+		has, err := d.bitswapHasBlock(ctx, ai.ID, c)
+		if err != nil {
+			result.BitswapError = err.Error()
+		} else {
+			result.DataAvailable = has
+		}
+
+		if err := d.host.Network().ClosePeer(ai.ID); err != nil {
+			log.Debugf("error closing probe connection to %s: %s", ai.ID, err)
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// bitswapHasBlock opens a bitswap stream to p and sends a want-have for c,
+// returning whether p reports having the block.
+func (d *Daemon) bitswapHasBlock(ctx context.Context, p peer.ID, c cid.Cid) (bool, error) {
+	s, err := d.host.NewStream(ctx, p, bitswapProtocol)
+	if err != nil {
+		return false, err
+	}
+	defer s.Close()
+
+	if err := s.SetDeadline(time.Now().Add(bitswapProbeTimeout)); err != nil {
+		return false, err
+	}
+
+	msg := bsmsg.New(false)
+	msg.AddEntry(c, 1, bsmsgpb.Message_Wantlist_Have, true)
+
+	if err := msg.ToNetV1(s); err != nil {
+		return false, err
+	}
+
+	reply, err := bsmsg.FromMsgReader(msgio.NewVarintReaderSize(s, network.MessageSizeMax))
+	if err != nil {
+		return false, err
+	}
+
+	for _, bp := range reply.BlockPresences() {
+		if bp.Cid.Equals(c) {
+			return bp.Type == bsmsgpb.Message_Have, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (d *Daemon) doCheckCID(req *pb.Request, w ggio.WriteCloser) {
+	c, err := cid.Cast(req.GetCheckCid().GetCid())
+	if err != nil {
+		writeErrorResponse(w, err)
+		return
+	}
+
+	results, err := d.CheckCID(d.ctx, c)
+	if err != nil {
+		writeErrorResponse(w, err)
+		return
+	}
+
+	for _, r := range results {
+		writeCheckCIDResponse(w, checkCIDResponseType(pb.CheckCIDResponse_VALUE), r)
+	}
+	writeCheckCIDResponse(w, checkCIDResponseType(pb.CheckCIDResponse_END), nil)
+}
+
+func writeCheckCIDResponse(w ggio.WriteCloser, t *pb.CheckCIDResponse_Type, r *ProviderCheck) {
+	okType := pb.Response_OK
+	checkCidResp := &pb.CheckCIDResponse{Type: t}
+
+	if r != nil {
+		dhtMaddrs := make([][]byte, len(r.DHTMaddrs))
+		for i, a := range r.DHTMaddrs {
+			dhtMaddrs[i] = a.Bytes()
+		}
+		connMaddrs := make([][]byte, len(r.ConnectedMaddrs))
+		for i, a := range r.ConnectedMaddrs {
+			connMaddrs[i] = a.Bytes()
+		}
+
+		checkCidResp.PeerId = []byte(r.PeerID)
+		checkCidResp.DhtMaddrs = dhtMaddrs
+		checkCidResp.ConnectedMaddrs = connMaddrs
+		checkCidResp.ConnectionError = strPtr(r.ConnectionError)
+		checkCidResp.DataAvailable = &r.DataAvailable
+		checkCidResp.BitswapError = strPtr(r.BitswapError)
+	}
+
+	resp := &pb.Response{
+		Type:     &okType,
+		CheckCid: checkCidResp,
+	}
+
+	if err := w.WriteMsg(resp); err != nil {
+		log.Debugf("error writing checkcid response: %s", err)
+	}
+}
+
+func checkCIDResponseType(t pb.CheckCIDResponse_Type) *pb.CheckCIDResponse_Type {
+	return &t
+}
+
+func strPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,351 @@
+package p2pd
+
+import (
+	"fmt"
+
+	ds "github.com/ipfs/go-ds-leveldb"
+	ggio "github.com/gogo/protobuf/io"
+	cid "github.com/ipfs/go-cid"
+	crypto "github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
+	peerstore "github.com/libp2p/go-libp2p-core/peerstore"
+	"github.com/libp2p/go-libp2p-core/protocol"
+	dht "github.com/libp2p/go-libp2p-kad-dht"
+	dhtopts "github.com/libp2p/go-libp2p-kad-dht/opts"
+	multiaddr "github.com/multiformats/go-multiaddr"
+
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+)
+
+// BootstrapPeers are the peers used by Daemon.Bootstrap when the daemon
+// wasn't configured with an explicit peer list; it defaults to the public
+// IPFS DHT bootstrap peers.
+var BootstrapPeers = dht.DefaultBootstrapPeers
+
+// defaultFindProvidersCount is used when a FIND_PROVIDERS request omits
+// count (the protobuf default of 0 would otherwise return no providers).
+const defaultFindProvidersCount = 20
+
+// DHTOption configures the DHT subsystem enabled by Daemon.EnableDHT.
+type DHTOption func([]dhtopts.Option) ([]dhtopts.Option, error)
+
+// DHTClientMode restricts the DHT to client mode: it queries the network
+// but doesn't serve records to other peers.
+func DHTClientMode() DHTOption {
+	return func(opts []dhtopts.Option) ([]dhtopts.Option, error) {
+		return append(opts, dhtopts.Client(true)), nil
+	}
+}
+
+// DHTMode selects the DHT's mode: "auto", "client", or "server".
+func DHTMode(mode string) DHTOption {
+	return func(opts []dhtopts.Option) ([]dhtopts.Option, error) {
+		switch mode {
+		case "", "auto":
+			return opts, nil
+		case "client":
+			return append(opts, dhtopts.Client(true)), nil
+		case "server":
+			return append(opts, dhtopts.Client(false)), nil
+		default:
+			return nil, fmt.Errorf("unknown DHT mode %q", mode)
+		}
+	}
+}
+
+// DHTProtocolPrefix overrides the DHT's protocol prefix, for running an
+// isolated DHT swarm alongside the public IPFS one.
+func DHTProtocolPrefix(prefix string) DHTOption {
+	return func(opts []dhtopts.Option) ([]dhtopts.Option, error) {
+		return append(opts, dhtopts.ProtocolPrefix(protocol.ID(prefix))), nil
+	}
+}
+
+// DHTDatastore persists the DHT's records to a leveldb datastore at path,
+// instead of the default in-memory store.
+func DHTDatastore(path string) DHTOption {
+	return func(opts []dhtopts.Option) ([]dhtopts.Option, error) {
+		store, err := ds.NewDatastore(path, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		return append(opts, dhtopts.Datastore(store)), nil
+	}
+}
+
+// EnableDHT initializes the DHT subsystem on the daemon's host.
+func (d *Daemon) EnableDHT(opts ...DHTOption) error {
+	var dopts []dhtopts.Option
+	for _, o := range opts {
+		var err error
+		dopts, err = o(dopts)
+		if err != nil {
+			return err
+		}
+	}
+
+	kad, err := dht.New(d.ctx, d.host, dopts...)
+	if err != nil {
+		return err
+	}
+
+	d.dht = kad
+	return nil
+}
+
+// Bootstrap connects the daemon's host to BootstrapPeers and, if the DHT is
+// enabled, bootstraps the DHT routing table.
+func (d *Daemon) Bootstrap() error {
+	for _, p := range BootstrapPeers {
+		maddr, err := multiaddr.NewMultiaddr(p)
+		if err != nil {
+			log.Warnf("invalid bootstrap peer %s: %s", p, err)
+			continue
+		}
+
+		ai, err := peerstore.InfoFromP2pAddr(maddr)
+		if err != nil {
+			log.Warnf("invalid bootstrap peer %s: %s", p, err)
+			continue
+		}
+
+		if err := d.host.Connect(d.ctx, *ai); err != nil {
+			log.Warnf("error connecting to bootstrap peer %s: %s", ai.ID, err)
+		}
+	}
+
+	if d.dht != nil {
+		return d.dht.Bootstrap(d.ctx)
+	}
+
+	return nil
+}
+
+// Provide announces to the DHT that this daemon can serve c.
+func (d *Daemon) Provide(c cid.Cid) error {
+	if d.dht == nil {
+		return fmt.Errorf("DHT is not enabled")
+	}
+
+	return d.dht.Provide(d.ctx, c, true)
+}
+
+// FindProviders queries the DHT for up to count peers that provide c,
+// streaming back each provider as it is found.
+func (d *Daemon) FindProviders(c cid.Cid, count int) (<-chan peerstore.PeerInfo, error) {
+	if d.dht == nil {
+		return nil, fmt.Errorf("DHT is not enabled")
+	}
+
+	return d.dht.FindProvidersAsync(d.ctx, c, count), nil
+}
+
+// FindPeer queries the DHT for the addresses of p.
+func (d *Daemon) FindPeer(p peer.ID) (peerstore.PeerInfo, error) {
+	if d.dht == nil {
+		return peerstore.PeerInfo{}, fmt.Errorf("DHT is not enabled")
+	}
+
+	return d.dht.FindPeer(d.ctx, p)
+}
+
+// GetValue queries the DHT for the value stored at key.
+func (d *Daemon) GetValue(key string) ([]byte, error) {
+	if d.dht == nil {
+		return nil, fmt.Errorf("DHT is not enabled")
+	}
+
+	return d.dht.GetValue(d.ctx, key)
+}
+
+// PutValue stores value at key in the DHT.
+func (d *Daemon) PutValue(key string, value []byte) error {
+	if d.dht == nil {
+		return fmt.Errorf("DHT is not enabled")
+	}
+
+	return d.dht.PutValue(d.ctx, key, value)
+}
+
+// GetPublicKey queries the DHT for the public key of p.
+func (d *Daemon) GetPublicKey(p peer.ID) (crypto.PubKey, error) {
+	if d.dht == nil {
+		return nil, fmt.Errorf("DHT is not enabled")
+	}
+
+	return d.dht.GetPublicKey(d.ctx, p)
+}
+
+// SearchValue queries the DHT for key, streaming back increasingly better
+// values as they are found.
+func (d *Daemon) SearchValue(key string) (<-chan []byte, error) {
+	if d.dht == nil {
+		return nil, fmt.Errorf("DHT is not enabled")
+	}
+
+	return d.dht.SearchValue(d.ctx, key)
+}
+
+func (d *Daemon) doDHT(req *pb.Request, w ggio.WriteCloser) {
+	if d.dht == nil {
+		writeErrorResponse(w, fmt.Errorf("DHT is not enabled"))
+		return
+	}
+
+	dhtreq := req.GetDht()
+
+	switch dhtreq.GetType() {
+	case pb.DHTRequest_PROVIDE:
+		d.doDHTProvide(dhtreq, w)
+	case pb.DHTRequest_FIND_PROVIDERS:
+		d.doDHTFindProviders(dhtreq, w)
+	case pb.DHTRequest_FIND_PEER:
+		d.doDHTFindPeer(dhtreq, w)
+	case pb.DHTRequest_GET_VALUE:
+		d.doDHTGetValue(dhtreq, w)
+	case pb.DHTRequest_PUT_VALUE:
+		d.doDHTPutValue(dhtreq, w)
+	case pb.DHTRequest_GET_PUBLIC_KEY:
+		d.doDHTGetPublicKey(dhtreq, w)
+	case pb.DHTRequest_SEARCH_VALUE:
+		d.doDHTSearchValue(dhtreq, w)
+	default:
+		writeErrorResponse(w, fmt.Errorf("unsupported DHT request type %s", dhtreq.GetType()))
+	}
+}
+
+func (d *Daemon) doDHTProvide(req *pb.DHTRequest, w ggio.WriteCloser) {
+	c, err := cid.Cast(req.GetCid())
+	if err != nil {
+		writeErrorResponse(w, err)
+		return
+	}
+
+	if err := d.Provide(c); err != nil {
+		writeErrorResponse(w, err)
+		return
+	}
+
+	writeDHTResponse(w, &pb.DHTResponse{Type: dhtResponseType(pb.DHTResponse_END)})
+}
+
+func (d *Daemon) doDHTFindProviders(req *pb.DHTRequest, w ggio.WriteCloser) {
+	c, err := cid.Cast(req.GetCid())
+	if err != nil {
+		writeErrorResponse(w, err)
+		return
+	}
+
+	count := int(req.GetCount())
+	if count == 0 {
+		count = defaultFindProvidersCount
+	}
+
+	ch, err := d.FindProviders(c, count)
+	if err != nil {
+		writeErrorResponse(w, err)
+		return
+	}
+
+	for ai := range ch {
+		writeDHTResponse(w, &pb.DHTResponse{
+			Type: dhtResponseType(pb.DHTResponse_VALUE),
+			Peer: peerInfoToPB(ai),
+		})
+	}
+	writeDHTResponse(w, &pb.DHTResponse{Type: dhtResponseType(pb.DHTResponse_END)})
+}
+
+func (d *Daemon) doDHTFindPeer(req *pb.DHTRequest, w ggio.WriteCloser) {
+	ai, err := d.FindPeer(peer.ID(req.GetPeer()))
+	if err != nil {
+		writeErrorResponse(w, err)
+		return
+	}
+
+	writeDHTResponse(w, &pb.DHTResponse{
+		Type: dhtResponseType(pb.DHTResponse_VALUE),
+		Peer: peerInfoToPB(ai),
+	})
+}
+
+func (d *Daemon) doDHTGetValue(req *pb.DHTRequest, w ggio.WriteCloser) {
+	val, err := d.GetValue(string(req.GetKey()))
+	if err != nil {
+		writeErrorResponse(w, err)
+		return
+	}
+
+	writeDHTResponse(w, &pb.DHTResponse{
+		Type:  dhtResponseType(pb.DHTResponse_VALUE),
+		Value: val,
+	})
+}
+
+func (d *Daemon) doDHTPutValue(req *pb.DHTRequest, w ggio.WriteCloser) {
+	if err := d.PutValue(string(req.GetKey()), req.GetValue()); err != nil {
+		writeErrorResponse(w, err)
+		return
+	}
+
+	writeDHTResponse(w, &pb.DHTResponse{Type: dhtResponseType(pb.DHTResponse_END)})
+}
+
+func (d *Daemon) doDHTGetPublicKey(req *pb.DHTRequest, w ggio.WriteCloser) {
+	pk, err := d.GetPublicKey(peer.ID(req.GetPeer()))
+	if err != nil {
+		writeErrorResponse(w, err)
+		return
+	}
+
+	raw, err := crypto.MarshalPublicKey(pk)
+	if err != nil {
+		writeErrorResponse(w, err)
+		return
+	}
+
+	writeDHTResponse(w, &pb.DHTResponse{
+		Type:  dhtResponseType(pb.DHTResponse_VALUE),
+		Value: raw,
+	})
+}
+
+func (d *Daemon) doDHTSearchValue(req *pb.DHTRequest, w ggio.WriteCloser) {
+	ch, err := d.SearchValue(string(req.GetKey()))
+	if err != nil {
+		writeErrorResponse(w, err)
+		return
+	}
+
+	for val := range ch {
+		writeDHTResponse(w, &pb.DHTResponse{
+			Type:  dhtResponseType(pb.DHTResponse_VALUE),
+			Value: val,
+		})
+	}
+	writeDHTResponse(w, &pb.DHTResponse{Type: dhtResponseType(pb.DHTResponse_END)})
+}
+
+func dhtResponseType(t pb.DHTResponse_Type) *pb.DHTResponse_Type {
+	return &t
+}
+
+func peerInfoToPB(ai peerstore.PeerInfo) *pb.PeerInfo {
+	addrs := make([][]byte, len(ai.Addrs))
+	for i, a := range ai.Addrs {
+		addrs[i] = a.Bytes()
+	}
+
+	return &pb.PeerInfo{
+		Id:    []byte(ai.ID),
+		Addrs: addrs,
+	}
+}
+
+func writeDHTResponse(w ggio.WriteCloser, dhtResp *pb.DHTResponse) {
+	okType := pb.Response_OK
+	if err := w.WriteMsg(&pb.Response{Type: &okType, Dht: dhtResp}); err != nil {
+		log.Debugf("error writing DHT response: %s", err)
+	}
+}
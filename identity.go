@@ -0,0 +1,77 @@
+package p2pd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	crypto "github.com/libp2p/go-libp2p-core/crypto"
+)
+
+// ReadIdentity loads a private key from the given file path, as produced by
+// crypto.MarshalPrivateKey.
+func ReadIdentity(path string) (crypto.PrivKey, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return crypto.UnmarshalPrivateKey(data)
+}
+
+// WriteIdentity atomically writes key to path with 0600 permissions.
+func WriteIdentity(path string, key crypto.PrivKey) error {
+	data, err := crypto.MarshalPrivateKey(key)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+// GenerateIdentity creates a new private key of the given type (one of the
+// crypto.KeyType constants, e.g. crypto.Ed25519 or crypto.RSA) and bit size
+// (ignored for Ed25519), and writes it to path.
+func GenerateIdentity(path string, keyType, bits int) (crypto.PrivKey, error) {
+	key, _, err := crypto.GenerateKeyPair(keyType, bits)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := WriteIdentity(path, key); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// LoadOrCreateIdentity reads the identity key at path, generating a new
+// Ed25519 key and writing it there if no file exists yet. This lets a
+// long-lived daemon always come up with a stable peer ID without requiring
+// a separate key-generation step.
+func LoadOrCreateIdentity(path string) (crypto.PrivKey, error) {
+	if _, err := os.Stat(path); err == nil {
+		return ReadIdentity(path)
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	return GenerateIdentity(path, crypto.Ed25519, 0)
+}
@@ -0,0 +1,86 @@
+package p2pd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	crypto "github.com/libp2p/go-libp2p-core/crypto"
+)
+
+func TestGenerateAndReadIdentity(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "identity.key")
+
+	key, err := GenerateIdentity(path, crypto.Ed25519, 0)
+	if err != nil {
+		t.Fatalf("GenerateIdentity: %s", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("stat generated key: %s", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("key file permissions = %o, want 0600", perm)
+	}
+
+	read, err := ReadIdentity(path)
+	if err != nil {
+		t.Fatalf("ReadIdentity: %s", err)
+	}
+	if !read.Equals(key) {
+		t.Error("key read back from disk does not match the generated key")
+	}
+}
+
+func TestWriteIdentityLeavesNoTempFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "identity.key")
+
+	key, _, err := crypto.GenerateKeyPair(crypto.Ed25519, 0)
+	if err != nil {
+		t.Fatalf("GenerateKeyPair: %s", err)
+	}
+
+	if err := WriteIdentity(path, key); err != nil {
+		t.Fatalf("WriteIdentity: %s", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir: %s", err)
+	}
+	if len(entries) != 1 || entries[0].Name() != "identity.key" {
+		t.Errorf("unexpected directory contents after WriteIdentity: %v", entries)
+	}
+}
+
+func TestLoadOrCreateIdentity(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "identity.key")
+
+	created, err := LoadOrCreateIdentity(path)
+	if err != nil {
+		t.Fatalf("LoadOrCreateIdentity (create): %s", err)
+	}
+
+	loaded, err := LoadOrCreateIdentity(path)
+	if err != nil {
+		t.Fatalf("LoadOrCreateIdentity (load): %s", err)
+	}
+
+	if !loaded.Equals(created) {
+		t.Error("LoadOrCreateIdentity generated a new key instead of loading the existing one")
+	}
+}
+
+func TestGenerateIdentityRSA(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "identity.key")
+
+	key, err := GenerateIdentity(path, crypto.RSA, 2048)
+	if err != nil {
+		t.Fatalf("GenerateIdentity: %s", err)
+	}
+	if key.Type() != crypto.RSA {
+		t.Errorf("key type = %v, want RSA", key.Type())
+	}
+}
@@ -0,0 +1,35 @@
+package p2pd
+
+import (
+	ggio "github.com/gogo/protobuf/io"
+	logging "github.com/ipfs/go-log/v2"
+
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+)
+
+// Logger returns the daemon's top-level event logger, so that callers
+// embedding the daemon can emit structured log events under the same
+// "p2pd" subsystem.
+func (d *Daemon) Logger() *logging.ZapEventLogger {
+	return log
+}
+
+// SetLogLevel sets the log level of subsystem to level ("debug", "info",
+// "warn", or "error").
+func (d *Daemon) SetLogLevel(subsystem, level string) error {
+	return logging.SetLogLevel(subsystem, level)
+}
+
+func (d *Daemon) doSetLogLevel(req *pb.Request, w ggio.WriteCloser) {
+	llreq := req.GetLogLevel()
+
+	if err := d.SetLogLevel(llreq.GetSubsystem(), llreq.GetLevel()); err != nil {
+		writeErrorResponse(w, err)
+		return
+	}
+
+	okType := pb.Response_OK
+	if err := w.WriteMsg(&pb.Response{Type: &okType}); err != nil {
+		log.Debugf("error writing setloglevel response: %s", err)
+	}
+}
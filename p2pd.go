@@ -0,0 +1,167 @@
+package p2pd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+
+	logging "github.com/ipfs/go-log/v2"
+	ggio "github.com/gogo/protobuf/io"
+	libp2p "github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p-core/host"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	dht "github.com/libp2p/go-libp2p-kad-dht"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	multiaddr "github.com/multiformats/go-multiaddr"
+
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+)
+
+var log = logging.Logger("p2pd")
+
+// Daemon wraps a libp2p host and serves requests over a control socket.
+type Daemon struct {
+	ctx context.Context
+
+	host host.Host
+
+	listener net.Listener
+
+	dht *dht.IpfsDHT
+	ps  *pubsub.PubSub
+
+	privateNetwork bool
+}
+
+// NewDaemon constructs a libp2p host with the given options, and starts
+// serving control-socket requests at sockPath.
+func NewDaemon(ctx context.Context, sockPath string, opts ...libp2p.Option) (*Daemon, error) {
+	h, err := libp2p.New(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.Remove(sockPath); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	l, err := net.Listen("unix", sockPath)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &Daemon{
+		ctx:      ctx,
+		host:     h,
+		listener: l,
+	}
+
+	go d.listen()
+
+	return d, nil
+}
+
+func (d *Daemon) listen() {
+	for {
+		c, err := d.listener.Accept()
+		if err != nil {
+			log.Errorf("error accepting connection: %s", err)
+			if d.ctx.Err() != nil {
+				return
+			}
+			continue
+		}
+
+		go d.handleConn(c)
+	}
+}
+
+func (d *Daemon) handleConn(c net.Conn) {
+	defer c.Close()
+
+	r := ggio.NewDelimitedReader(c, network.MessageSizeMax)
+	w := ggio.NewDelimitedWriter(c)
+
+	var req pb.Request
+	if err := r.ReadMsg(&req); err != nil {
+		log.Debugf("error reading request: %s", err)
+		return
+	}
+
+	switch req.GetType() {
+	case pb.Request_IDENTIFY:
+		d.doIdentify(w)
+	case pb.Request_PUBSUB:
+		d.doPubsub(&req, c, w)
+	case pb.Request_DHT:
+		d.doDHT(&req, w)
+	case pb.Request_PNET:
+		d.doPnet(w)
+	case pb.Request_SETLOGLEVEL:
+		d.doSetLogLevel(&req, w)
+	case pb.Request_CHECKCID:
+		d.doCheckCID(&req, w)
+	default:
+		writeErrorResponse(w, fmt.Errorf("unsupported request type %s", req.GetType()))
+	}
+}
+
+func (d *Daemon) doIdentify(w ggio.WriteCloser) {
+	var addrs [][]byte
+	for _, a := range d.host.Addrs() {
+		addrs = append(addrs, a.Bytes())
+	}
+
+	okType := pb.Response_OK
+	resp := &pb.Response{
+		Type: &okType,
+		Identify: &pb.IdentifyResponse{
+			Id:    []byte(d.host.ID()),
+			Addrs: addrs,
+		},
+	}
+
+	if err := w.WriteMsg(resp); err != nil {
+		log.Debugf("error writing identify response: %s", err)
+	}
+}
+
+func writeErrorResponse(w ggio.WriteCloser, err error) {
+	errType := pb.Response_ERROR
+	msg := err.Error()
+	resp := &pb.Response{
+		Type:  &errType,
+		Error: &pb.ErrorResponse{Msg: &msg},
+	}
+
+	if werr := w.WriteMsg(resp); werr != nil {
+		log.Debugf("error writing error response: %s", werr)
+	}
+}
+
+// SetPrivateNetwork records whether the daemon's host was constructed with
+// libp2p.PrivateNetwork, so that it can be reported over the control
+// socket. It must be called, if at all, before the host starts dialing.
+func (d *Daemon) SetPrivateNetwork(enabled bool) {
+	d.privateNetwork = enabled
+}
+
+// ID returns the peer ID of the daemon's host.
+func (d *Daemon) ID() peer.ID {
+	return d.host.ID()
+}
+
+// Addrs returns the listen addresses of the daemon's host.
+func (d *Daemon) Addrs() []multiaddr.Multiaddr {
+	return d.host.Addrs()
+}
+
+// Close shuts down the control socket listener and the underlying host.
+func (d *Daemon) Close() error {
+	if err := d.listener.Close(); err != nil {
+		log.Warnf("error closing listener: %s", err)
+	}
+	return d.host.Close()
+}
@@ -2,29 +2,105 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"flag"
 	"fmt"
-	"log"
+	"strconv"
 	"strings"
+	"time"
 
 	libp2p "github.com/libp2p/go-libp2p"
 	p2pd "github.com/libp2p/go-libp2p-daemon"
+	connmgr "github.com/libp2p/go-libp2p-connmgr"
+	cid "github.com/ipfs/go-cid"
+	crypto "github.com/libp2p/go-libp2p-core/crypto"
+	peer "github.com/libp2p/go-libp2p-core/peer"
+	logging "github.com/ipfs/go-log/v2"
+	noise "github.com/libp2p/go-libp2p-noise"
+	quic "github.com/libp2p/go-libp2p-quic-transport"
+	tls "github.com/libp2p/go-libp2p-tls"
 	identify "github.com/libp2p/go-libp2p/p2p/protocol/identify"
+	tcp "github.com/libp2p/go-tcp-transport"
+	ws "github.com/libp2p/go-ws-transport"
 )
 
+var log = logging.Logger("p2pd/main")
+
 func main() {
 	identify.ClientVersion = "p2pd/0.1"
 
 	sock := flag.String("sock", "/tmp/p2pd.sock", "daemon control socket path")
-	quiet := flag.Bool("q", false, "be quiet")
 	id := flag.String("id", "", "peer identity; private key file")
+	idGenerate := flag.Bool("idGenerate", false, "generates a new identity at the -id path, prints its peer ID, and exits")
+	idKeyType := flag.String("idKeyType", "Ed25519", "key type for -idGenerate: Ed25519 or RSA")
+	idBits := flag.Int("idBits", 0, "key size in bits for -idGenerate; ignored for Ed25519")
 	bootstrap := flag.Bool("b", false, "connects to bootstrap peers and bootstraps the dht if enabled")
 	bootstrapPeers := flag.String("bootstrapPeers", "", "comma separated list of bootstrap peers; defaults to the IPFS DHT peers")
 	dht := flag.Bool("dht", false, "Enables the DHT in full node mode")
 	dhtClient := flag.Bool("dhtClient", false, "Enables the DHT in client mode")
+	dhtMode := flag.String("dhtMode", "", "DHT mode: auto, client, or server; overrides -dhtClient")
+	dhtProtocolPrefix := flag.String("dhtProtocolPrefix", "", "protocol prefix for the DHT, for running an isolated DHT swarm")
+	dhtDatastore := flag.String("dhtDatastore", "", "path to a leveldb datastore for the DHT; defaults to an in-memory store")
+	pubsubEnable := flag.Bool("pubsub", false, "Enables pubsub")
+	pubsubRouter := flag.String("pubsubRouter", "gossipsub", "Specifies the pubsub router implementation (gossipsub or floodsub)")
+	pubsubSign := flag.Bool("pubsubSign", true, "Sign pubsub messages with the host's private key")
+	quicEnable := flag.Bool("quic", false, "Enables the QUIC transport")
+	tcpEnable := flag.Bool("tcp", true, "Enables the TCP transport")
+	wsEnable := flag.Bool("ws", false, "Enables the WebSocket transport")
+	tlsEnable := flag.Bool("tls", true, "Enables TLS 1.3 as a security transport")
+	noiseEnable := flag.Bool("noise", false, "Enables Noise as a security transport")
+	connmgrSpec := flag.String("connmgr", "", "low,high,graceperiod for the connection manager, e.g. 100,400,30s")
+	listen := flag.String("listen", "", "comma separated list of listen multiaddrs; defaults to the libp2p defaults")
+	pnet := flag.String("pnet", "", "path to a libp2p swarm key file; runs the host in a private network")
+	logLevel := flag.String("logLevel", "error", "log level for all subsystems: debug, info, warn, error")
+	logSubsystem := flag.String("logSubsystem", "", "comma separated subsystem=level pairs, e.g. dht=debug,pubsub=warn")
+	check := flag.String("check", "", "CID to check for provider reachability and data availability; prints a JSON report and exits")
 	flag.Parse()
 
+	if *idGenerate {
+		if *id == "" {
+			log.Fatal("-idGenerate requires -id=<path>")
+		}
+
+		keyType, err := keyTypeFromString(*idKeyType)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		key, err := p2pd.GenerateIdentity(*id, keyType, *idBits)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		pid, err := peer.IDFromPrivateKey(key)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("Generated identity %s at %s\n", pid.Pretty(), *id)
+		return
+	}
+
+	if err := logging.SetLogLevel("*", *logLevel); err != nil {
+		log.Fatal(err)
+	}
+	for _, pair := range strings.Split(*logSubsystem, ",") {
+		if pair == "" {
+			continue
+		}
+
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 {
+			log.Fatalf("invalid -logSubsystem entry %q: expected subsystem=level", pair)
+		}
+
+		if err := logging.SetLogLevel(kv[0], kv[1]); err != nil {
+			log.Fatal(err)
+		}
+	}
+
 	var opts []libp2p.Option
+	privateNetwork := *pnet != ""
 
 	if *id != "" {
 		key, err := p2pd.ReadIdentity(*id)
@@ -35,13 +111,74 @@ func main() {
 		opts = append(opts, libp2p.Identity(key))
 	}
 
+	if privateNetwork {
+		psk, err := p2pd.ReadPSK(*pnet)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		opts = append(opts, libp2p.PrivateNetwork(psk))
+		p2pd.BootstrapPeers = nil
+	}
+
+	if *quicEnable {
+		opts = append(opts, libp2p.Transport(quic.NewTransport))
+	}
+	if *tcpEnable {
+		opts = append(opts, libp2p.Transport(tcp.NewTCPTransport))
+	}
+	if *wsEnable {
+		opts = append(opts, libp2p.Transport(ws.New))
+	}
+
+	if *tlsEnable {
+		opts = append(opts, libp2p.Security(tls.ID, tls.New))
+	}
+	if *noiseEnable {
+		opts = append(opts, libp2p.Security(noise.ID, noise.New))
+	}
+
+	if *listen != "" {
+		opts = append(opts, libp2p.ListenAddrStrings(strings.Split(*listen, ",")...))
+	}
+
+	if *connmgrSpec != "" {
+		cm, err := newConnManager(*connmgrSpec)
+		if err != nil {
+			log.Fatal(err)
+		}
+		opts = append(opts, libp2p.ConnectionManager(cm))
+	}
+
 	d, err := p2pd.NewDaemon(context.Background(), *sock, opts...)
 	if err != nil {
 		log.Fatal(err)
 	}
+	d.SetPrivateNetwork(privateNetwork)
+
+	if *dht || *dhtClient || *check != "" {
+		var dhtOpts []p2pd.DHTOption
+		if *dhtClient && *dhtMode == "" {
+			dhtOpts = append(dhtOpts, p2pd.DHTClientMode())
+		}
+		if *dhtMode != "" {
+			dhtOpts = append(dhtOpts, p2pd.DHTMode(*dhtMode))
+		}
+		if *dhtProtocolPrefix != "" {
+			dhtOpts = append(dhtOpts, p2pd.DHTProtocolPrefix(*dhtProtocolPrefix))
+		}
+		if *dhtDatastore != "" {
+			dhtOpts = append(dhtOpts, p2pd.DHTDatastore(*dhtDatastore))
+		}
+
+		err = d.EnableDHT(dhtOpts...)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
 
-	if *dht || *dhtClient {
-		err = d.EnableDHT(*dhtClient)
+	if *pubsubEnable {
+		err = d.EnablePubSub(*pubsubRouter, *pubsubSign)
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -58,20 +195,74 @@ func main() {
 		}
 	}
 
-	if !*quiet {
-		fmt.Printf("Control socket: %s\n", *sock)
-		fmt.Printf("Peer ID: %s\n", d.ID().Pretty())
-		fmt.Printf("Peer Addrs:\n")
-		for _, addr := range d.Addrs() {
-			fmt.Printf("%s\n", addr.String())
+	if *check != "" {
+		c, err := cid.Decode(*check)
+		if err != nil {
+			log.Fatal(err)
 		}
-		if *bootstrap && *bootstrapPeers != "" {
-			fmt.Printf("Bootstrap peers:\n")
-			for _, p := range p2pd.BootstrapPeers {
-				fmt.Printf("%s\n", p)
-			}
+
+		results, err := d.CheckCID(context.Background(), c)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		report, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Println(string(report))
+		return
+	}
+
+	log.Infof("Control socket: %s", *sock)
+	log.Infof("Peer ID: %s", d.ID().Pretty())
+	for _, addr := range d.Addrs() {
+		log.Infof("Peer Addr: %s", addr.String())
+	}
+	if *bootstrap && *bootstrapPeers != "" {
+		for _, p := range p2pd.BootstrapPeers {
+			log.Infof("Bootstrap peer: %s", p)
 		}
 	}
 
 	select {}
 }
+
+// keyTypeFromString maps the -idKeyType flag to a crypto.KeyType constant.
+func keyTypeFromString(s string) (int, error) {
+	switch s {
+	case "Ed25519", "ed25519":
+		return crypto.Ed25519, nil
+	case "RSA", "rsa":
+		return crypto.RSA, nil
+	default:
+		return 0, fmt.Errorf("unknown key type %q: expected Ed25519 or RSA", s)
+	}
+}
+
+// newConnManager parses a "low,high,graceperiod" spec, as accepted by the
+// -connmgr flag, into a connection manager.
+func newConnManager(spec string) (*connmgr.BasicConnMgr, error) {
+	parts := strings.Split(spec, ",")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("invalid -connmgr spec %q: expected low,high,graceperiod", spec)
+	}
+
+	low, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid -connmgr low watermark: %w", err)
+	}
+
+	high, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid -connmgr high watermark: %w", err)
+	}
+
+	grace, err := time.ParseDuration(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("invalid -connmgr grace period: %w", err)
+	}
+
+	return connmgr.NewConnManager(low, high, grace), nil
+}
@@ -0,0 +1,38 @@
+package p2pd
+
+import (
+	"os"
+
+	ggio "github.com/gogo/protobuf/io"
+	corepnet "github.com/libp2p/go-libp2p-core/pnet"
+	libp2ppnet "github.com/libp2p/go-libp2p-pnet"
+
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+)
+
+// ReadPSK loads a libp2p pre-shared key from the standard
+// /key/swarm/psk/1.0.0/ base16 file format, for use with
+// libp2p.PrivateNetwork.
+func ReadPSK(path string) (corepnet.PSK, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return libp2ppnet.DecodeV1PSK(f)
+}
+
+func (d *Daemon) doPnet(w ggio.WriteCloser) {
+	enabled := d.privateNetwork
+
+	okType := pb.Response_OK
+	resp := &pb.Response{
+		Type: &okType,
+		Pnet: &pb.PNetResponse{Enabled: &enabled},
+	}
+
+	if err := w.WriteMsg(resp); err != nil {
+		log.Debugf("error writing pnet response: %s", err)
+	}
+}
@@ -0,0 +1,140 @@
+package p2pd
+
+import (
+	"fmt"
+	"net"
+
+	ggio "github.com/gogo/protobuf/io"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+)
+
+// EnablePubSub initializes the pubsub subsystem on the daemon's host, using
+// either the gossipsub or floodsub router. When sign is true, published
+// messages are signed with the host's private key.
+func (d *Daemon) EnablePubSub(router string, sign bool) error {
+	var opts []pubsub.Option
+	if !sign {
+		opts = append(opts, pubsub.WithMessageSigning(false))
+	}
+
+	var ps *pubsub.PubSub
+	var err error
+
+	switch router {
+	case "", "gossipsub":
+		ps, err = pubsub.NewGossipSub(d.ctx, d.host, opts...)
+	case "floodsub":
+		ps, err = pubsub.NewFloodSub(d.ctx, d.host, opts...)
+	default:
+		return fmt.Errorf("unknown pubsub router %q", router)
+	}
+	if err != nil {
+		return err
+	}
+
+	d.ps = ps
+	return nil
+}
+
+func (d *Daemon) doPubsub(req *pb.Request, c net.Conn, w ggio.WriteCloser) {
+	if d.ps == nil {
+		writeErrorResponse(w, fmt.Errorf("pubsub is not enabled"))
+		return
+	}
+
+	psreq := req.GetPubsub()
+
+	switch psreq.GetType() {
+	case pb.PSRequest_GET_TOPICS:
+		d.doPubsubGetTopics(w)
+	case pb.PSRequest_LIST_PEERS:
+		d.doPubsubListPeers(psreq.GetTopic(), w)
+	case pb.PSRequest_PUBLISH:
+		d.doPubsubPublish(psreq.GetTopic(), psreq.GetData(), w)
+	case pb.PSRequest_SUBSCRIBE:
+		d.doPubsubSubscribe(psreq.GetTopic(), c, w)
+	default:
+		writeErrorResponse(w, fmt.Errorf("unsupported pubsub request type %s", psreq.GetType()))
+	}
+}
+
+func (d *Daemon) doPubsubGetTopics(w ggio.WriteCloser) {
+	okType := pb.Response_OK
+	resp := &pb.Response{
+		Type:   &okType,
+		Pubsub: &pb.PSResponse{Topics: d.ps.GetTopics()},
+	}
+
+	if err := w.WriteMsg(resp); err != nil {
+		log.Debugf("error writing pubsub response: %s", err)
+	}
+}
+
+func (d *Daemon) doPubsubListPeers(topic string, w ggio.WriteCloser) {
+	peers := d.ps.ListPeers(topic)
+	peerIDs := make([][]byte, len(peers))
+	for i, p := range peers {
+		peerIDs[i] = []byte(p)
+	}
+
+	okType := pb.Response_OK
+	resp := &pb.Response{
+		Type:   &okType,
+		Pubsub: &pb.PSResponse{PeerIDs: peerIDs},
+	}
+
+	if err := w.WriteMsg(resp); err != nil {
+		log.Debugf("error writing pubsub response: %s", err)
+	}
+}
+
+func (d *Daemon) doPubsubPublish(topic string, data []byte, w ggio.WriteCloser) {
+	if err := d.ps.Publish(topic, data); err != nil {
+		writeErrorResponse(w, err)
+		return
+	}
+
+	okType := pb.Response_OK
+	if err := w.WriteMsg(&pb.Response{Type: &okType}); err != nil {
+		log.Debugf("error writing pubsub response: %s", err)
+	}
+}
+
+// doPubsubSubscribe streams one PSMessage frame per pubsub message received
+// on topic, for as long as the control-socket connection stays open.
+func (d *Daemon) doPubsubSubscribe(topic string, c net.Conn, w ggio.WriteCloser) {
+	sub, err := d.ps.Subscribe(topic)
+	if err != nil {
+		writeErrorResponse(w, err)
+		return
+	}
+	defer sub.Cancel()
+
+	okType := pb.Response_OK
+	if err := w.WriteMsg(&pb.Response{Type: &okType}); err != nil {
+		log.Debugf("error writing pubsub response: %s", err)
+		return
+	}
+
+	for {
+		msg, err := sub.Next(d.ctx)
+		if err != nil {
+			log.Debugf("pubsub subscription to %s ended: %s", topic, err)
+			return
+		}
+
+		out := &pb.PSMessage{
+			From:     []byte(msg.From),
+			Data:     msg.Data,
+			Seqno:    msg.Seqno,
+			TopicIDs: msg.TopicIDs,
+		}
+
+		if err := w.WriteMsg(out); err != nil {
+			log.Debugf("error streaming pubsub message: %s", err)
+			return
+		}
+	}
+}